@@ -0,0 +1,106 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * Timing table and a correctness demonstration for composite and prime lengths, built on top of
+ * the fft package. This binary is a thin wrapper: all the algorithms live in fft.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************
+ * To run this file, just type:
+ *
+ * $ go run ./cmd/anyfft
+ *
+ * If you want to compile to have an executable file, then build it by issuing the command:
+ *
+ * $ go build ./cmd/anyfft
+ **************************************************************************************************/
+
+package main
+
+
+/**************************************************************************************************
+ Include necessary libraries:
+ **************************************************************************************************/
+import (
+    "fmt"                                                           // String and output formatting;
+
+    "github.com/jnalon/fast-fourier-transform/go/fft"               // The FFT algorithms;
+)
+
+
+/**************************************************************************************************
+ Definitions:
+ **************************************************************************************************/
+const REPEAT = 500                     // Number of executions to compute average time;
+
+
+/**************************************************************************************************
+ Main Function:
+ **************************************************************************************************/
+func main() {
+
+    SIZES := [8]int{ 2*3, 2*2*3, 2*3*3, 2*3*5, 2*2*3*3, 2*2*5*5, 2*3*5*7, 2*2*3*3*5*5 };
+
+    // Start by printing the table with time comparisons:
+    fmt.Println("+---------+---------+---------+---------+")
+    fmt.Println("|    N    |   N^2   | Direct  | Recurs. |")
+    fmt.Println("+---------+---------+---------+---------+")
+
+    // Try it with vectors with the given sizes:
+    for i:=0; i<8; i++ {
+
+        // Compute the average execution time:
+        n := SIZES[i]
+        dtime := fft.TimeIt(fft.DirectFT, n, REPEAT)
+        rtime := fft.TimeIt(fft.RecursiveFFT, n, REPEAT)
+
+        // Print the results:
+        fmt.Printf("| %7d | %7d | %7.4f | %7.4f |\n",
+                n, n*n, dtime, rtime)
+    }
+
+    fmt.Println("+---------+---------+---------+---------+")
+    fmt.Println()
+
+    PRIMES := [3]int{ 509, 1021, 2039 };
+
+    // Primes are the worst case for RecursiveFFT, since it used to fall back to the direct form;
+    // Bluestein fixes that, so compare it against direct computation and against RecursiveFFT,
+    // which now dispatches to Bluestein automatically for large primes:
+    fmt.Println("+---------+---------+---------+---------+---------+")
+    fmt.Println("|    N    |   N^2   | Direct  | Bluest. | Recurs. |")
+    fmt.Println("+---------+---------+---------+---------+---------+")
+
+    for i:=0; i<3; i++ {
+
+        // Compute the average execution time:
+        n := PRIMES[i]
+        dtime := fft.TimeIt(fft.DirectFT, n, REPEAT)
+        btime := fft.TimeIt(fft.BluesteinFFT, n, REPEAT)
+        rtime := fft.TimeIt(fft.RecursiveFFT, n, REPEAT)
+
+        // Print the results:
+        fmt.Printf("| %7d | %7d | %7.4f | %7.4f | %7.4f |\n",
+                n, n*n, dtime, btime, rtime)
+    }
+
+    fmt.Println("+---------+---------+---------+---------+---------+")
+    fmt.Println()
+
+    // Shows that the inverse FFT recovers the original signal, even for a prime length, now
+    // using the Planner API, which picks Bluestein automatically for this length:
+    x := []complex128{ 2, 3, 5, 7, 11 }
+    plan := fft.NewPlan(len(x), fft.Forward)
+    iplan := fft.NewPlan(len(x), fft.Inverse)
+
+    X := make([]complex128, len(x))
+    plan.Execute(x, X)
+    y := make([]complex128, len(x))
+    iplan.Execute(X, y)
+    y = fft.Clean(y, 1e-10)
+
+    fmt.Println("Original vector:")
+    fft.ComplexShow(x)
+    fmt.Println("Recovered after IFFT(FFT(x)):")
+    fft.ComplexShow(y)
+
+}
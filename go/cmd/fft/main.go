@@ -0,0 +1,148 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * Timing table and a correctness demonstration for the power-of-two kernels, built on top of the
+ * fft package. This binary is a thin wrapper: all the algorithms live in fft.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************
+ * To run this file, just type:
+ *
+ * $ go run ./cmd/fft
+ *
+ * If you want to compile to have an executable file, then build it by issuing the command:
+ *
+ * $ go build ./cmd/fft
+ **************************************************************************************************/
+
+package main
+
+
+/**************************************************************************************************
+ Include necessary libraries:
+ **************************************************************************************************/
+import (
+    "fmt"                                                           // String and output formatting;
+    "math"                                                          // Math functions;
+
+    "github.com/jnalon/fast-fourier-transform/go/fft"               // The FFT algorithms;
+)
+
+
+/**************************************************************************************************
+ Definitions:
+ **************************************************************************************************/
+const REPEAT = 500                     // Number of executions to compute average time;
+
+
+/**************************************************************************************************
+ * Function: directReference
+ *   Computes the DFT directly from its definition, with every term's twiddle factor computed from
+ *   its own angle instead of accumulated by repeated multiplication like DirectFT, so it carries no
+ *   phase drift of its own and is fit to measure other kernels' drift against.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the DFT will be computed.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func directReference(x []complex128) []complex128 {
+    n := len(x)
+    X := make([]complex128, n)
+    for k:=0; k<n; k++ {
+        for j:=0; j<n; j++ {
+            X[k] = X[k] + x[j]*fft.CExp(-2*math.Pi*float64(k*j)/float64(n))
+        }
+    }
+    return X
+}
+
+
+/**************************************************************************************************
+ Main Function:
+ **************************************************************************************************/
+func main() {
+
+    // Starts by printing the table with time comparisons:
+    fmt.Println("+---------+---------+---------+---------+---------+---------+---------+---------+")
+    fmt.Println("|    N    |   N^2   | N logN  | Direta  | Recurs. | It.DIT  | It.DIF  | SplitR. |")
+    fmt.Println("+---------+---------+---------+---------+---------+---------+---------+---------+")
+
+    // Try it with vectors with size ranging from 32 to 1024 samples:
+    for r:=5; r<11; r++ {
+
+        // Computes the average execution time:
+        n := int(math.Exp2(float64(r)))
+        dtime := fft.TimeIt(fft.DirectFT, n, REPEAT)
+        rtime := fft.TimeIt(fft.RecursiveFFT, n, REPEAT)
+        itime := fft.TimeIt(fft.IterativeFFT, n, REPEAT)
+        ftime := fft.TimeIt(fft.IterativeFFT_DIF, n, REPEAT)
+        stime := fft.TimeIt(fft.SplitRadixFFT, n, REPEAT)
+
+        // Print the results:
+        fmt.Printf("| %7d | %7d | %7d | %7.4f | %7.4f | %7.4f | %7.4f | %7.4f |\n",
+                n, n*n, r*n, dtime, rtime, itime, ftime, stime)
+    }
+
+    fmt.Println("+---------+---------+---------+---------+---------+---------+---------+---------+")
+    fmt.Println()
+
+    // The twiddle table removes the accumulated phase drift of repeated multiplication; DirectFT
+    // accumulates its own twiddles the same way, so it can't show that off. Compare instead
+    // against a reference that computes each term's angle from scratch, and would carry no drift
+    // of its own even for a moderate size:
+    n := 1024
+    ref := make([]complex128, n)
+    for j:=0; j<n; j++ {
+        ref[j] = complex(float64(j), 0)
+    }
+    Xd := directReference(ref)
+    Xs := fft.SplitRadixFFT(ref)
+    maxerr := 0.0
+    for j:=0; j<n; j++ {
+        diff := Xd[j] - Xs[j]
+        err := math.Sqrt(real(diff)*real(diff) + imag(diff)*imag(diff))
+        if err > maxerr {
+            maxerr = err
+        }
+    }
+    fmt.Printf("Split-radix max error against a per-index reference, N=%d: %e\n\n", n, maxerr)
+
+    // Throughput on larger sizes, where split-radix and the twiddle table pay off the most:
+    fmt.Println("+---------+---------+---------+---------+")
+    fmt.Println("|    N    | Recurs. | It.DIT  | SplitR. |")
+    fmt.Println("+---------+---------+---------+---------+")
+
+    for r:=10; r<17; r++ {
+
+        // Computes the average execution time, with fewer repetitions given the larger sizes:
+        n := int(math.Exp2(float64(r)))
+        rtime := fft.TimeIt(fft.RecursiveFFT, n, REPEAT/10)
+        itime := fft.TimeIt(fft.IterativeFFT, n, REPEAT/10)
+        stime := fft.TimeIt(fft.SplitRadixFFT, n, REPEAT/10)
+
+        // Print the results:
+        fmt.Printf("| %7d | %7.4f | %7.4f | %7.4f |\n", n, rtime, itime, stime)
+    }
+
+    fmt.Println("+---------+---------+---------+---------+")
+    fmt.Println()
+
+    // Shows that the inverse FFT recovers the original signal, now using the Planner API:
+    x := []complex128{ 2, 3, 5, 7, 11, 13, 17, 19 }
+    plan := fft.NewPlan(len(x), fft.Forward)
+    iplan := fft.NewPlan(len(x), fft.Inverse)
+
+    X := make([]complex128, len(x))
+    plan.Execute(x, X)
+    y := make([]complex128, len(x))
+    iplan.Execute(X, y)
+    y = fft.Clean(y, 1e-10)
+
+    fmt.Println("Original vector:")
+    fft.ComplexShow(x)
+    fmt.Println("Recovered after IFFT(FFT(x)):")
+    fft.ComplexShow(y)
+
+}
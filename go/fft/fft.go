@@ -0,0 +1,612 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * This package gathers the Fast Fourier Transform algorithms previously split between the two
+ * standalone `fft.go` and `anyfft.go` programs -- iterative and recursive, decimation in time and
+ * in frequency, split-radix, and Bluestein's algorithm for arbitrary lengths -- and adds a Plan
+ * type that picks and caches the best one for a given size, so repeated calls don't pay the setup
+ * cost (or the allocations) more than once.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************/
+
+// Package fft implements the Fast Fourier Transform and a selection of related algorithms.
+package fft
+
+
+/**************************************************************************************************
+ Include necessary libraries:
+ **************************************************************************************************/
+import (
+    "fmt"                              // String and output formatting;
+    "math"                             // Math functions;
+    "sync"                             // Guards the package-level caches;
+    "time"                             // Time measuring;
+)
+
+
+/**************************************************************************************************
+ Definitions:
+ **************************************************************************************************/
+const PRIME_THRESHOLD = 32             // Above this length, primes are handled by Bluestein
+                                        //   instead of the O(N^2) direct form;
+
+
+/**************************************************************************************************
+ * Auxiliary function: CExp
+ *   Computes the complex exponential of an angle. Convenience function.
+ *
+ * Parameters:
+ *   a
+ *     Angle
+ *
+ * Returns
+ *   A complex number with the complex exponential of the angle.
+ **************************************************************************************************/
+func CExp(a float64) complex128 {
+    return complex(math.Cos(a), math.Sin(a))
+}
+
+
+/**************************************************************************************************
+ * Auxiliary function: ComplexShow
+ *   Pretty printing of an array of complex numbers, used to inspect results.
+ *
+ * Parameters:
+ *   x
+ *     A vector of complex numbers;
+ **************************************************************************************************/
+func ComplexShow(x []complex128) {
+    for i:=0; i<len(x); i++ {
+        fmt.Printf("( %7.4f, %7.4f )\n", real(x[i]), imag(x[i]))
+    }
+}
+
+
+/**************************************************************************************************
+ * Auxiliary function: TimeIt
+ *   This function calls a Fast Fourier Transform function repeatedly a certain number of times,
+ *   measure execution time and average it.
+ *
+ * Parameters:
+ *  f
+ *    Function to be called, with the given prototype. It takes the input vector and returns the
+ *    result of the computation;
+ *  size
+ *    Number of elements in the vector on which the transform will be applied;
+ *  repeat
+ *    Number of times the function will be called.
+ *
+ * Returns:
+ *   The average execution time for that function with a vector of the given size.
+ **************************************************************************************************/
+func TimeIt(f func([]complex128) []complex128, size int, repeat int) float64 {
+    x := make([]complex128, size)              // Initialize the vector;
+    for j:=0; j<size; j++ {
+        x[j] = complex(float64(j), 0)
+    }
+    t0 := time.Now()                           // Starting time;
+    for j:=0; j<repeat; j++ {
+        f(x)
+    }
+    t1 := time.Since(t0)
+    return time.Duration.Seconds(t1) / float64(repeat)
+}
+
+
+/**************************************************************************************************
+ * Function: DirectFT
+ *   Computes the Discrete Fourier Ttransform directly from the definition, an algorithm that has
+ *   O(N^2) complexity.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the DFT will be computed. Given the nature of the implementation, there
+ *     is no restriction on the size of the vector, although it will almost always be called with a
+ *     power of two size to give a fair comparison;
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func DirectFT(x []complex128) []complex128 {
+    N := len(x)
+    X := make([]complex128, N)                 // Accumulates the results;
+    W := CExp(-2*math.Pi/float64(N))           // Initializes twiddle factors:
+    Wk := complex(1, 0)
+    for k:=0; k<N; k++ {
+        Wkn := complex(1, 0)                   // Initializes twiddle factors;
+        for n:=0; n<N; n++ {
+            X[k] = X[k] + x[n]*Wkn
+            Wkn = Wkn * Wk                     // Update twiddle factor;
+        }
+        Wk = Wk * W
+    }
+    return X
+}
+
+
+/**************************************************************************************************
+ * Function: Factor
+ *   Smallest prime factor of a given number. If the argument is prime itself, then it is the
+ *   return value.
+ *
+ * Parameters:
+ *   n
+ *     Number to be inspected.
+ *
+ * Returns:
+ *   The smallest prime factor, or the number itself if it is already a prime.
+ **************************************************************************************************/
+func Factor(n int) int {
+    rn := int(math.Ceil(math.Sqrt(float64(n))))    // Search up to the square root of the number;
+    for i:=2; i<=rn; i++ {
+        if n%i == 0 {
+            return i                               // If remainder is zero, a factor is found;
+        }
+    }
+    return n
+}
+
+
+/**************************************************************************************************
+ * Type: TwiddleTable
+ *   Caches the twiddle factors W_N^k for a given N, so they don't need to be recomputed (or
+ *   accumulated by repeated multiplication, which drifts from the true value) on every call. When
+ *   N is even, only indices 0..N/2-1 are stored, and the rest follow from the symmetry
+ *   W_N^(k+N/2) = -W_N^k; that symmetry doesn't hold for odd N, so the full table is kept instead.
+ **************************************************************************************************/
+type TwiddleTable struct {
+    N int
+    W []complex128
+}
+
+
+/**************************************************************************************************
+ * Method: At
+ *   Looks up W_N^k for any k, using the half-table and the W_N^(k+N/2) = -W_N^k symmetry when N
+ *   is even, and the full table directly when N is odd.
+ *
+ * Parameters:
+ *   k
+ *     The exponent of the twiddle factor.
+ *
+ * Returns:
+ *   The twiddle factor W_N^k.
+ **************************************************************************************************/
+func (t *TwiddleTable) At(k int) complex128 {
+    k = k % t.N
+    if k < 0 {
+        k = k + t.N
+    }
+    if t.N%2 != 0 {                            // No half-table symmetry to exploit when N is odd;
+        return t.W[k]
+    }
+    N2 := t.N / 2
+    if k < N2 {
+        return t.W[k]
+    }
+    return -t.W[k-N2]
+}
+
+
+/**************************************************************************************************
+ Twiddle table cache, indexed by N, and the function used to populate it. Guarded by a mutex,
+ since the cache is shared by every call into the package and Plan-based callers are expected to
+ be used from multiple goroutines:
+ **************************************************************************************************/
+var twiddleCacheMu sync.Mutex
+var twiddleCache = map[int]*TwiddleTable{}
+
+func getTwiddleTable(N int) *TwiddleTable {
+    twiddleCacheMu.Lock()
+    defer twiddleCacheMu.Unlock()
+
+    if t, ok := twiddleCache[N]; ok {
+        return t
+    }
+    size := N / 2                              // Half the table suffices for even N, thanks to
+    if N%2 != 0 {                               //   the W_N^(k+N/2) = -W_N^k symmetry;
+        size = N
+    }
+    W := make([]complex128, size)              // Computed once per N, each entry from its own
+    for k:=0; k<size; k++ {                    //   angle rather than accumulated by repeated
+        W[k] = CExp(-2*math.Pi*float64(k)/float64(N))  //   multiplication, which drifts;
+    }
+    t := &TwiddleTable{N: N, W: W}
+    twiddleCache[N] = t
+    return t
+}
+
+
+/**************************************************************************************************
+ * Function: RecursiveFFT
+ *   Computes the Fast Fourier Ttransform using a recursive mixed-radix decimation in time
+ *   algorithm. Its complexity depends on how composite N is; if N is prime, the computation is
+ *   deferred to the direct form, or to BluesteinFFT if N is large.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the FFT will be computed. Its length must be a composite number, or else
+ *     the computation will be defered to the direct (or Bluestein) form, with no efficiency gain.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func RecursiveFFT(x []complex128) []complex128 {
+    N := len(x)
+    X := make([]complex128, N)
+    N1 := Factor(N)                            // Smallest prime factor of length;
+    if N1 == N {                               // If the length is prime itself,
+        if N > PRIME_THRESHOLD {               //   large primes are handled with Bluestein,
+            return BluesteinFFT(x)
+        }
+        return DirectFT(x)                     //   small ones by the direct form;
+    } else {
+        N2 := N / N1                           // Decompose in two factors, N1 being prime;
+        xj := make([]complex128, N2)           // Allocate memory for subsequences
+        T := getTwiddleTable(N)                // Twiddle factors, looked up instead of
+        for j:=0; j<N1; j++ {                  //   accumulated, so there is no phase drift;
+            for n:=0; n<N2; n++ {
+                xj[n] = x[n*N1+j]              // Create the subsequence;
+            }
+            Xj := RecursiveFFT(xj)             // Compute the DFT of the subsequence;
+            for k:=0; k<N; k++ {
+                X[k] = X[k] + Xj[k%N2]*T.At(j*k)   // Recombine results;
+            }
+        }
+        return X
+    }
+}
+
+
+/**************************************************************************************************
+ * Function: BitReverse
+ *   Computes the bit-reversed function of an integer number.
+ *
+ * Parameters:
+ *   k
+ *     The number to be bit-reversed;
+ *   r
+ *     The number of bits to take into consideration when reversing.
+ *
+ * Returns:
+ *   The number k, bit-reversed according to integers with r bits.
+ **************************************************************************************************/
+func BitReverse(k int, r int) int {
+    l := 0                                     // Accumulates the results;
+    for i:=0; i<r; i++ {                       // Loop on every bit;
+        l = (l << 1) + (k & 1)                 // Tests less signficant bit and add;
+        k = k >> 1                             // Tests next bit;
+    }
+    return l
+}
+
+
+/**************************************************************************************************
+ * Function: bitReverseInPlace
+ *   Permutes a vector into bit-reversed order, in place, by swapping each pair of elements whose
+ *   indices are bit-reversed images of each other. Shared by both iterative FFT variants so the
+ *   permutation doesn't need to be allocated into a fresh output vector every time.
+ *
+ * Parameters:
+ *   X
+ *     The vector to be permuted, modified in place;
+ *   r
+ *     The number of bits to take into consideration when reversing the indices.
+ **************************************************************************************************/
+func bitReverseInPlace(X []complex128, r int) {
+    N := len(X)
+    for k:=0; k<N; k++ {
+        l := BitReverse(k, r)
+        if l > k {                             // Swap only once per pair;
+            X[k], X[l] = X[l], X[k]
+        }
+    }
+}
+
+
+/**************************************************************************************************
+ * Function: IterativeFFT
+ *   Computes the Fast Fourier Ttransform using an iterative in-place decimation in time algorithm.
+ *   This has O(N log_2(N)) complexity, and since there are less function calls, it will probably
+ *   be marginally faster than the recursive versions.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the FFT will be computed. This should always be called with a vector of
+ *     a power of two length, or it will fail. No checks on this are made.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func IterativeFFT(x []complex128) []complex128 {
+    N := len(x)
+    r := int(math.Floor(math.Log(float64(N))/math.Log(2)))     // Number of bits;
+
+    X := make([]complex128, N)
+    copy(X, x)
+    bitReverseInPlace(X, r)                    // Pre-pass: reorder the vector in bit-reversed order;
+
+    step := 1                                  // Auxiliary for computation of twiddle factors;
+    for k:=0; k<r; k++ {
+        T := getTwiddleTable(2*step)           // Twiddle factors, looked up instead of
+        for l:=0; l<N; l=l+2*step {            //   accumulated, so there is no phase drift;
+            for n:=0; n<step; n++ {
+                Wkn := T.At(n)
+                p := l + n
+                q := p + step
+                X[q] = X[p] - Wkn * X[q]       // Recombine results;
+                X[p] = 2*X[p] - X[q]
+             }
+        }
+        step = step << 1
+    }
+    return X
+}
+
+
+/**************************************************************************************************
+ * Function: IterativeFFT_DIF
+ *   Computes the Fast Fourier Ttransform using an iterative in-place decimation in frequency
+ *   algorithm (the Gentleman-Sande structure). Butterflies run from stride N/2 down to 1, with
+ *   (a, b) -> (a+b, (a-b).W^k), and the output is left in bit-reversed order, so a post-pass
+ *   un-scrambles it. This has the same O(N log_2(N)) complexity as the decimation in time version.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the FFT will be computed. This should always be called with a vector of
+ *     a power of two length, or it will fail. No checks on this are made.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func IterativeFFT_DIF(x []complex128) []complex128 {
+    N := len(x)
+    r := int(math.Floor(math.Log(float64(N))/math.Log(2)))     // Number of bits;
+
+    X := make([]complex128, N)
+    copy(X, x)
+
+    step := N / 2                              // Auxiliary for computation of twiddle factors;
+    for k:=0; k<r; k++ {
+        for l:=0; l<N; l=l+2*step {
+            W := CExp(-math.Pi/float64(step))  // Twiddle factors;
+            Wkn := complex(1, 0)
+            for n:=0; n<step; n++ {
+                p := l + n
+                q := p + step
+                a := X[p]                      // Recombine results;
+                b := X[q]
+                X[p] = a + b
+                X[q] = (a - b) * Wkn
+                Wkn = Wkn * W                   // Update twiddle factors;
+            }
+        }
+        step = step >> 1
+    }
+
+    bitReverseInPlace(X, r)                    // Post-pass: undo the bit-reversed order;
+    return X
+}
+
+
+/**************************************************************************************************
+ * Function: SplitRadixFFT
+ *   Computes the Fast Fourier Ttransform using the recursive split-radix (radix-2/4) decomposition.
+ *   The even samples are decimated as in the plain radix-2 algorithm, but the odd samples are
+ *   further split into two quarter-length subsequences, which reduces the number of nontrivial
+ *   complex multiplications with respect to the plain Cooley-Tukey recursion.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the FFT will be computed. This should always be called with a vector of
+ *     a power of two length, or it will fail. No checks on this are made.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func SplitRadixFFT(x []complex128) []complex128 {
+    N := len(x)
+    if N == 1 {                                // A length-1 vector is its own FT;
+        return x
+    }
+    if N == 2 {                                // A length-2 vector is its own butterfly;
+        return []complex128{ x[0]+x[1], x[0]-x[1] }
+    }
+
+    N2 := N / 2
+    N4 := N / 4
+
+    xe := make([]complex128, N2)               // Even samples, decimated as in plain radix-2;
+    xo1 := make([]complex128, N4)              // Odd samples, split into two quarter sequences;
+    xo3 := make([]complex128, N4)
+    for i:=0; i<N2; i++ {
+        xe[i] = x[2*i]
+    }
+    for i:=0; i<N4; i++ {
+        xo1[i] = x[4*i+1]
+        xo3[i] = x[4*i+3]
+    }
+    Xe := SplitRadixFFT(xe)                    // Transform of even samples;
+    Xo1 := SplitRadixFFT(xo1)                  // Transform of the two quarter subsequences;
+    Xo3 := SplitRadixFFT(xo3)
+
+    X := make([]complex128, N)
+    T := getTwiddleTable(N)
+    for k:=0; k<N4; k++ {
+        a := T.At(k) * Xo1[k]                  // Recombine results;
+        b := T.At(3*k) * Xo3[k]
+        u := a + b
+        v := complex(0, -1) * (a - b)
+        X[k] = Xe[k] + u
+        X[k+N2] = Xe[k] - u
+        X[k+N4] = Xe[k+N4] + v
+        X[k+N2+N4] = Xe[k+N4] - v
+    }
+
+    return X
+}
+
+
+/**************************************************************************************************
+ * Function: BluesteinFFT
+ *   Computes the Discrete Fourier Transform of an arbitrary length N, including primes, in
+ *   O(M log M) time, where M is the smallest power of two not smaller than 2N-1. The trick,
+ *   due to Bluestein, is to rewrite the DFT as a convolution, X[k] = w_k . sum(x[n].w_n . v[k-n]),
+ *   with w_n = exp(-j.pi.n^2/N), and compute that convolution with the power-of-two IterativeFFT.
+ *
+ * Parameters:
+ *   x
+ *     The vector of which the DFT will be computed. There is no restriction on its length.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func BluesteinFFT(x []complex128) []complex128 {
+    N := len(x)
+    M := 1                                      // Smallest power of two not smaller than 2N-1;
+    for M < 2*N-1 {
+        M = M << 1
+    }
+
+    w := make([]complex128, N)                  // Chirp: w_n = exp(-j.pi.n^2/N);
+    for n:=0; n<N; n++ {
+        w[n] = CExp(-math.Pi*float64(n*n)/float64(N))
+    }
+
+    a := make([]complex128, M)                  // x[n].w[n], zero-padded to length M;
+    for n:=0; n<N; n++ {
+        a[n] = x[n] * w[n]
+    }
+    b := make([]complex128, M)                  // v[k] = conj(w[k]), wrapped around so that the
+    b[0] = complex(real(w[0]), -imag(w[0]))      //   convolution is circular in the padded domain;
+    for n:=1; n<N; n++ {
+        bn := complex(real(w[n]), -imag(w[n]))
+        b[n] = bn
+        b[M-n] = bn
+    }
+
+    A := IterativeFFT(a)                        // Convolution theorem: convolve via the FFT;
+    B := IterativeFFT(b)
+    C := make([]complex128, M)
+    for i:=0; i<M; i++ {
+        C[i] = A[i] * B[i]
+    }
+    for i:=0; i<M; i++ {                        // Inverse FFT of C, through the conjugation trick;
+        C[i] = complex(real(C[i]), -imag(C[i]))
+    }
+    c := IterativeFFT(C)
+    for i:=0; i<M; i++ {
+        c[i] = complex(real(c[i])/float64(M), -imag(c[i])/float64(M))
+    }
+
+    X := make([]complex128, N)                  // Post-multiply by the chirp to recover the DFT;
+    for k:=0; k<N; k++ {
+        X[k] = w[k] * c[k]
+    }
+    return X
+}
+
+
+/**************************************************************************************************
+ * Function: InverseFFT
+ *   Inverse Fast Fourier Transform, computed for any length through the conjugation trick: the
+ *   input is conjugated, the forward transform is taken, and then the result is conjugated and
+ *   scaled back by the length of the vector.
+ *
+ * Parameters:
+ *   X
+ *     The vector of which the inverse FFT will be computed. There is no restriction on its length.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the samples recovered from the spectrum.
+ **************************************************************************************************/
+func InverseFFT(X []complex128) []complex128 {
+    N := len(X)
+    x := make([]complex128, N)                  // Conjugate the input;
+    for i:=0; i<N; i++ {
+        x[i] = complex(real(X[i]), -imag(X[i]))
+    }
+
+    x = RecursiveFFT(x)                          // Forward transform of the conjugated input;
+
+    for i:=0; i<N; i++ {                         // Conjugate again and scale by N;
+        x[i] = complex(real(x[i])/float64(N), -imag(x[i])/float64(N))
+    }
+    return x
+}
+
+
+/**************************************************************************************************
+ * Function: RealFFT
+ *   Fast Fourier Transform of a real-valued signal, computed for any even length. Pairs of samples
+ *   are packed into a half-length complex vector, which is transformed with RecursiveFFT; the full
+ *   spectrum is then recovered from the symmetries of the DFT of a real sequence.
+ *
+ * Parameters:
+ *   x
+ *     The vector of real samples of which the FFT will be computed. Its length must be even, or it
+ *     will fail. No checks on this are made.
+ *
+ * Returns:
+ *   A complex-number vector of the same size, with the coefficients of the DFT.
+ **************************************************************************************************/
+func RealFFT(x []float64) []complex128 {
+    N := len(x)
+    N2 := N / 2
+
+    z := make([]complex128, N2)                 // Pack pairs of real samples into a complex one;
+    for i:=0; i<N2; i++ {
+        z[i] = complex(x[2*i], x[2*i+1])
+    }
+    Z := RecursiveFFT(z)                        // Half-length complex transform;
+
+    X := make([]complex128, N)
+    for k:=0; k<=N2; k++ {                      // Unscramble the result;
+        kk := (N2 - k) % N2
+        Zc := complex(real(Z[kk]), -imag(Z[kk]))
+        Wk := CExp(-2*math.Pi*float64(k)/float64(N))
+        X[k] = 0.5*(Z[k%N2]+Zc) - complex(0, 0.5)*Wk*(Z[k%N2]-Zc)
+    }
+    for k:=N2+1; k<N; k++ {                     // The rest follows by conjugate symmetry;
+        X[k] = complex(real(X[N-k]), -imag(X[N-k]))
+    }
+    return X
+}
+
+
+/**************************************************************************************************
+ * Function: Clean
+ *   Zeroes the real and imaginary parts of the components of a spectrum that fall below a given
+ *   tolerance, to clean up the residual floating-point noise typical of FFT computations.
+ *
+ * Parameters:
+ *   X
+ *     The spectrum to be cleaned;
+ *   tol
+ *     Tolerance below which a component is considered to be zero.
+ *
+ * Returns:
+ *   A new complex-number vector, with the small components replaced by zero.
+ **************************************************************************************************/
+func Clean(X []complex128, tol float64) []complex128 {
+    Y := make([]complex128, len(X))
+    for i:=0; i<len(X); i++ {
+        re, im := real(X[i]), imag(X[i])
+        if math.Abs(re) < tol {
+            re = 0
+        }
+        if math.Abs(im) < tol {
+            im = 0
+        }
+        Y[i] = complex(re, im)
+    }
+    return Y
+}
+
+
+/**************************************************************************************************
+ Helper: isPowerOfTwo
+ **************************************************************************************************/
+func isPowerOfTwo(n int) bool {
+    return n > 0 && n&(n-1) == 0
+}
@@ -0,0 +1,100 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * Correctness tests for the transforms in fft.go, and a concurrency test for its twiddle cache.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************/
+
+package fft
+
+
+/**************************************************************************************************
+ Include necessary libraries:
+ **************************************************************************************************/
+import (
+    "math"
+    "sync"
+    "testing"
+)
+
+
+/**************************************************************************************************
+ Definitions:
+ **************************************************************************************************/
+const tolerance = 1e-9                 // Acceptable round-trip error for the checks below;
+
+
+/**************************************************************************************************
+ * Auxiliary function: maxAbsDiff
+ *   Largest magnitude of the component-wise difference between two complex vectors, used to
+ *   compare a transform's result against a reference.
+ **************************************************************************************************/
+func maxAbsDiff(a, b []complex128) float64 {
+    maxerr := 0.0
+    for i:=0; i<len(a); i++ {
+        diff := a[i] - b[i]
+        err := math.Sqrt(real(diff)*real(diff) + imag(diff)*imag(diff))
+        if err > maxerr {
+            maxerr = err
+        }
+    }
+    return maxerr
+}
+
+
+/**************************************************************************************************
+ * Test: TestInverseFFTRoundTrip
+ *   Checks that InverseFFT(RecursiveFFT(x)) recovers x, for a prime-length vector, which forces
+ *   RecursiveFFT to fall back to the direct form.
+ **************************************************************************************************/
+func TestInverseFFTRoundTrip(t *testing.T) {
+    x := []complex128{ 2, 3, 5, 7, 11 }
+    X := RecursiveFFT(x)
+    y := InverseFFT(X)
+
+    if err := maxAbsDiff(x, y); err > tolerance {
+        t.Errorf("IFFT(FFT(x)) strayed from x by %e, want <= %e", err, tolerance)
+    }
+}
+
+
+/**************************************************************************************************
+ * Test: TestRealFFT
+ *   Checks that RealFFT matches the direct transform of the same samples packed as complex
+ *   numbers with a zero imaginary part.
+ **************************************************************************************************/
+func TestRealFFT(t *testing.T) {
+    x := []float64{ 2, 3, 5, 7, 11, 13, 17, 19 }
+
+    z := make([]complex128, len(x))
+    for i, v := range x {
+        z[i] = complex(v, 0)
+    }
+
+    X := RealFFT(x)
+    Z := DirectFT(z)
+
+    if err := maxAbsDiff(X, Z); err > tolerance {
+        t.Errorf("RealFFT(x) strayed from DirectFT(x) by %e, want <= %e", err, tolerance)
+    }
+}
+
+
+/**************************************************************************************************
+ * Test: TestGetTwiddleTableConcurrent
+ *   Calls getTwiddleTable for a handful of sizes from many goroutines at once, to catch a
+ *   concurrent map read/write on twiddleCache under `go test -race`.
+ **************************************************************************************************/
+func TestGetTwiddleTableConcurrent(t *testing.T) {
+    var wg sync.WaitGroup
+    for g:=0; g<32; g++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for _, n := range []int{8, 16, 32, 64} {
+                getTwiddleTable(n)
+            }
+        }()
+    }
+    wg.Wait()
+}
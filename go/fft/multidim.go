@@ -0,0 +1,320 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * Multi-dimensional transforms, built on top of the Planner API: FFT2D and FFTN perform the
+ * transform as successive 1-D FFTs along each axis of a row-major array, reusing a cached Plan per
+ * axis length instead of building a new one for every line. FFTShift/IFFTShift move the
+ * zero-frequency component to the center of the spectrum, matching numpy's semantics, which is
+ * what convolution-based filtering of images and other multi-dimensional signals needs.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************/
+
+package fft
+
+
+/**************************************************************************************************
+ Include necessary libraries:
+ **************************************************************************************************/
+import (
+    "sync"                             // Guards the plan cache;
+)
+
+
+/**************************************************************************************************
+ Plan cache, shared by FFTN/IFFTN so that repeated calls with the same axis lengths don't rebuild
+ the same Plan over and over. Guarded by a mutex, since the cache is shared by every call into the
+ package and is expected to be used from multiple goroutines:
+ **************************************************************************************************/
+type planKey struct {
+    N   int
+    Dir Direction
+}
+
+var planCacheMu sync.Mutex
+var planCache = map[planKey]*Plan{}
+
+func getPlan(n int, dir Direction) *Plan {
+    planCacheMu.Lock()
+    defer planCacheMu.Unlock()
+
+    key := planKey{n, dir}
+    if p, ok := planCache[key]; ok {
+        return p
+    }
+    p := NewPlan(n, dir)
+    planCache[key] = p
+    return p
+}
+
+
+/**************************************************************************************************
+ * Function: fftN
+ *   Transforms a row-major flattened array along every axis in shape, one axis at a time, by
+ *   slicing it into 1-D lines of the right stride and running each through the cached Plan for
+ *   that axis length and direction.
+ *
+ * Parameters:
+ *   x
+ *     The row-major flattened array to be transformed;
+ *   shape
+ *     The length of each dimension, outermost first;
+ *   dir
+ *     Forward or Inverse.
+ *
+ * Returns:
+ *   A new row-major flattened array, of the same total size as x, with the transform.
+ **************************************************************************************************/
+func fftN(x []complex128, shape []int, dir Direction) []complex128 {
+    total := 1
+    for _, s := range shape {
+        total = total * s
+    }
+
+    strides := make([]int, len(shape))         // Row-major strides: the last axis is contiguous;
+    strides[len(shape)-1] = 1
+    for d:=len(shape)-2; d>=0; d-- {
+        strides[d] = strides[d+1] * shape[d+1]
+    }
+
+    result := make([]complex128, total)
+    copy(result, x)
+
+    for axis, n := range shape {
+        plan := *getPlan(n, dir)               // A copy of the cached Plan: it shares the cached
+        plan.scratch = make([]complex128, n)   //   tables, but gets its own scratch buffers, since
+        if plan.pad != nil {                   //   the cached Plan is shared by every goroutine;
+            plan.pad = make([]complex128, plan.M)
+        }
+        stride := strides[axis]
+        block := stride * n                    // Size of one contiguous block along this axis;
+
+        in := make([]complex128, n)
+        out := make([]complex128, n)
+        for base:=0; base<total; base=base+block {
+            for off:=0; off<stride; off++ {
+                start := base + off
+                for k:=0; k<n; k++ {            // Gather one line along the axis;
+                    in[k] = result[start+k*stride]
+                }
+                plan.Execute(in, out)
+                for k:=0; k<n; k++ {            // Scatter the transformed line back;
+                    result[start+k*stride] = out[k]
+                }
+            }
+        }
+    }
+    return result
+}
+
+
+/**************************************************************************************************
+ * Function: FFTN
+ *   Computes the forward Fast Fourier Transform of a row-major flattened N-dimensional array, one
+ *   axis at a time, reusing a cached Plan per axis length.
+ *
+ * Parameters:
+ *   x
+ *     The row-major flattened array to be transformed;
+ *   shape
+ *     The length of each dimension, outermost first. Their product must equal len(x).
+ *
+ * Returns:
+ *   A new row-major flattened array, of the same total size as x, with the coefficients of the
+ *   N-dimensional DFT.
+ **************************************************************************************************/
+func FFTN(x []complex128, shape []int) []complex128 {
+    return fftN(x, shape, Forward)
+}
+
+
+/**************************************************************************************************
+ * Function: IFFTN
+ *   Computes the inverse Fast Fourier Transform of a row-major flattened N-dimensional array, the
+ *   companion of FFTN.
+ *
+ * Parameters:
+ *   x
+ *     The row-major flattened array to be transformed;
+ *   shape
+ *     The length of each dimension, outermost first. Their product must equal len(x).
+ *
+ * Returns:
+ *   A new row-major flattened array, of the same total size as x, with the samples recovered from
+ *   the spectrum.
+ **************************************************************************************************/
+func IFFTN(x []complex128, shape []int) []complex128 {
+    return fftN(x, shape, Inverse)
+}
+
+
+/**************************************************************************************************
+ * Function: FFT2D
+ *   Computes the forward Fast Fourier Transform of a 2-D array, by flattening it in row-major
+ *   order and delegating to FFTN.
+ *
+ * Parameters:
+ *   x
+ *     The 2-D array to be transformed. All rows must have the same length.
+ *
+ * Returns:
+ *   A new 2-D array, of the same dimensions as x, with the coefficients of the 2-D DFT.
+ **************************************************************************************************/
+func FFT2D(x [][]complex128) [][]complex128 {
+    return apply2D(x, FFTN)
+}
+
+
+/**************************************************************************************************
+ * Function: IFFT2D
+ *   Computes the inverse Fast Fourier Transform of a 2-D array, the companion of FFT2D.
+ *
+ * Parameters:
+ *   x
+ *     The 2-D array to be transformed. All rows must have the same length.
+ *
+ * Returns:
+ *   A new 2-D array, of the same dimensions as x, with the samples recovered from the spectrum.
+ **************************************************************************************************/
+func IFFT2D(x [][]complex128) [][]complex128 {
+    return apply2D(x, IFFTN)
+}
+
+
+/**************************************************************************************************
+ * Function: apply2D
+ *   Flattens a 2-D array in row-major order, runs it through one of FFTN/IFFTN, and reshapes the
+ *   result back into a 2-D array. Shared by FFT2D and IFFT2D so they don't duplicate the
+ *   flatten/reshape bookkeeping.
+ **************************************************************************************************/
+func apply2D(x [][]complex128, transform func([]complex128, []int) []complex128) [][]complex128 {
+    rows := len(x)
+    cols := len(x[0])
+
+    flat := make([]complex128, rows*cols)
+    for i:=0; i<rows; i++ {
+        copy(flat[i*cols:(i+1)*cols], x[i])
+    }
+
+    flat = transform(flat, []int{rows, cols})
+
+    result := make([][]complex128, rows)
+    for i:=0; i<rows; i++ {
+        result[i] = make([]complex128, cols)
+        copy(result[i], flat[i*cols:(i+1)*cols])
+    }
+    return result
+}
+
+
+/**************************************************************************************************
+ * Function: roll
+ *   Cyclically rotates a vector by shift positions, wrapping around. Shared by FFTShift and
+ *   IFFTShift, which only differ in which way they roll.
+ **************************************************************************************************/
+func roll(x []complex128, shift int) []complex128 {
+    N := len(x)
+    shift = ((shift % N) + N) % N
+    y := make([]complex128, N)
+    for i:=0; i<N; i++ {
+        y[(i+shift)%N] = x[i]
+    }
+    return y
+}
+
+
+/**************************************************************************************************
+ * Function: FFTShift
+ *   Cyclically rotates a spectrum so that the zero-frequency component moves to the center,
+ *   matching numpy.fft.fftshift.
+ *
+ * Parameters:
+ *   x
+ *     The spectrum to be shifted.
+ *
+ * Returns:
+ *   A new vector, of the same size as x, with the zero-frequency component centered.
+ **************************************************************************************************/
+func FFTShift(x []complex128) []complex128 {
+    return roll(x, len(x)/2)
+}
+
+
+/**************************************************************************************************
+ * Function: IFFTShift
+ *   Undoes FFTShift, matching numpy.fft.ifftshift. Only differs from FFTShift for odd lengths.
+ *
+ * Parameters:
+ *   x
+ *     The spectrum to be shifted back.
+ *
+ * Returns:
+ *   A new vector, of the same size as x, with the zero-frequency component back at index 0.
+ **************************************************************************************************/
+func IFFTShift(x []complex128) []complex128 {
+    N := len(x)
+    return roll(x, N-N/2)
+}
+
+
+/**************************************************************************************************
+ * Function: FFTShift2D
+ *   Cyclically rotates a 2-D spectrum so that the zero-frequency component moves to the center of
+ *   both axes, matching numpy.fft.fftshift applied to a 2-D array.
+ *
+ * Parameters:
+ *   x
+ *     The 2-D spectrum to be shifted. All rows must have the same length.
+ *
+ * Returns:
+ *   A new 2-D array, of the same dimensions as x, with the zero-frequency component centered.
+ **************************************************************************************************/
+func FFTShift2D(x [][]complex128) [][]complex128 {
+    return shift2D(x, FFTShift)
+}
+
+
+/**************************************************************************************************
+ * Function: IFFTShift2D
+ *   Undoes FFTShift2D, matching numpy.fft.ifftshift applied to a 2-D array.
+ *
+ * Parameters:
+ *   x
+ *     The 2-D spectrum to be shifted back. All rows must have the same length.
+ *
+ * Returns:
+ *   A new 2-D array, of the same dimensions as x, with the zero-frequency component back at (0,0).
+ **************************************************************************************************/
+func IFFTShift2D(x [][]complex128) [][]complex128 {
+    return shift2D(x, IFFTShift)
+}
+
+
+/**************************************************************************************************
+ * Function: shift2D
+ *   Shifts a 2-D array along both axes, by shifting every row and then shifting the rows
+ *   themselves, using the given 1-D shift function. Shared by FFTShift2D and IFFTShift2D.
+ **************************************************************************************************/
+func shift2D(x [][]complex128, shift1D func([]complex128) []complex128) [][]complex128 {
+    rows := len(x)
+
+    cols := make([][]complex128, rows)         // Shift within every row first;
+    for i:=0; i<rows; i++ {
+        cols[i] = shift1D(x[i])
+    }
+
+    rowVec := make([]complex128, rows)         // Then shift the rows themselves, column by column;
+    result := make([][]complex128, rows)
+    for i:=0; i<rows; i++ {
+        result[i] = make([]complex128, len(cols[i]))
+    }
+    for j:=0; j<len(cols[0]); j++ {
+        for i:=0; i<rows; i++ {
+            rowVec[i] = cols[i][j]
+        }
+        shifted := shift1D(rowVec)
+        for i:=0; i<rows; i++ {
+            result[i][j] = shifted[i]
+        }
+    }
+    return result
+}
@@ -0,0 +1,38 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * Concurrency regression test for the plan cache in multidim.go.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************/
+
+package fft
+
+
+/**************************************************************************************************
+ Include necessary libraries:
+ **************************************************************************************************/
+import (
+    "sync"
+    "testing"
+)
+
+
+/**************************************************************************************************
+ * Test: TestGetPlanConcurrent
+ *   Calls getPlan for a handful of sizes and both directions from many goroutines at once, to
+ *   catch a concurrent map read/write on planCache under `go test -race`.
+ **************************************************************************************************/
+func TestGetPlanConcurrent(t *testing.T) {
+    var wg sync.WaitGroup
+    for g:=0; g<32; g++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for _, n := range []int{8, 9, 16} {
+                getPlan(n, Forward)
+                getPlan(n, Inverse)
+            }
+        }()
+    }
+    wg.Wait()
+}
@@ -0,0 +1,254 @@
+/**************************************************************************************************
+ * Fast Fourier Transform -- Go Version
+ * Planner API: chooses the best kernel for a given transform length at construction time, and
+ * caches everything that doesn't depend on the input data (twiddle factors, bit-reversal
+ * permutation, Bluestein's chirp tables) so that repeated Execute calls don't pay that cost, or
+ * the allocations, more than once.
+ *
+ * José Alexandre Nalon
+ **************************************************************************************************/
+
+package fft
+
+import "math"
+
+
+/**************************************************************************************************
+ Definitions:
+ **************************************************************************************************/
+
+// Direction selects whether a Plan computes the forward or the inverse transform.
+type Direction int
+
+const (
+    Forward Direction = iota
+    Inverse
+)
+
+// kernel identifies which algorithm a Plan was built to run.
+type kernel int
+
+const (
+    kernelRadix2 kernel = iota
+    kernelMixedRadix
+    kernelBluestein
+)
+
+
+/**************************************************************************************************
+ * Type: Plan
+ *   Holds everything a repeated transform of a given length and direction needs, precomputed once:
+ *   which kernel to use, its twiddle factors and bit-reversal permutation (for the power-of-two
+ *   kernel) or its chirp tables (for Bluestein), and scratch buffers reused across Execute calls.
+ *   Execute mutates those scratch buffers in place, so a single Plan is not safe to Execute from
+ *   multiple goroutines at once; give each goroutine its own Plan (NewPlan and the package-level
+ *   caches it draws from are themselves safe to call concurrently).
+ **************************************************************************************************/
+type Plan struct {
+    N    int
+    Dir  Direction
+    kind kernel
+
+    twiddles *TwiddleTable                     // Radix-2: cached twiddle factors for N;
+    bitrev   []int                              // Radix-2: cached bit-reversal permutation;
+
+    M        int                                 // Bluestein: zero-padded convolution length;
+    chirp    []complex128                        // Bluestein: w_n = exp(-j.pi.n^2/N), cached;
+    chirpFFT []complex128                        // Bluestein: FFT of the wrapped chirp, cached;
+    pad      []complex128                        // Bluestein: reusable length-M scratch buffer;
+
+    scratch []complex128                        // Reusable length-N input/output buffer;
+}
+
+
+/**************************************************************************************************
+ * Function: NewPlan
+ *   Builds a Plan for transforms of length n: the radix-2 iterative kernel when n is a power of
+ *   two, the recursive mixed-radix kernel when n is otherwise composite, and Bluestein's algorithm
+ *   when n is prime, however small. Everything that only depends on n and dir is computed here, once.
+ *
+ * Parameters:
+ *   n
+ *     Length of the vectors the plan will transform;
+ *   dir
+ *     Forward or Inverse.
+ *
+ * Returns:
+ *   A Plan ready to Execute transforms of length n.
+ **************************************************************************************************/
+func NewPlan(n int, dir Direction) *Plan {
+    p := &Plan{N: n, Dir: dir, scratch: make([]complex128, n)}
+
+    switch {
+    case isPowerOfTwo(n):
+        p.kind = kernelRadix2
+        p.twiddles = getTwiddleTable(n)
+        r := 0
+        for 1<<uint(r) < n {
+            r++
+        }
+        p.bitrev = make([]int, n)
+        for k:=0; k<n; k++ {
+            p.bitrev[k] = BitReverse(k, r)
+        }
+    case Factor(n) != n:
+        p.kind = kernelMixedRadix
+    default:
+        p.kind = kernelBluestein
+        p.setupBluestein()
+    }
+    return p
+}
+
+
+/**************************************************************************************************
+ * Method: setupBluestein
+ *   Precomputes the chirp sequence and its zero-padded forward transform, the parts of Bluestein's
+ *   algorithm that only depend on N, so Execute doesn't recompute them on every call.
+ **************************************************************************************************/
+func (p *Plan) setupBluestein() {
+    N := p.N
+    M := 1
+    for M < 2*N-1 {
+        M = M << 1
+    }
+    p.M = M
+
+    w := make([]complex128, N)
+    for n:=0; n<N; n++ {
+        w[n] = CExp(-math.Pi*float64(n*n) / float64(N))
+    }
+    p.chirp = w
+
+    b := make([]complex128, M)
+    b[0] = complex(real(w[0]), -imag(w[0]))
+    for n:=1; n<N; n++ {
+        bn := complex(real(w[n]), -imag(w[n]))
+        b[n] = bn
+        b[M-n] = bn
+    }
+    p.chirpFFT = IterativeFFT(b)
+    p.pad = make([]complex128, M)
+}
+
+
+/**************************************************************************************************
+ * Method: Execute
+ *   Runs the plan's transform, reading in and writing the result to out. Both must have length N.
+ *   The forward/inverse direction is handled by the conjugation trick around whichever kernel the
+ *   plan was built with. The radix-2 kernel works in place on the plan's cached scratch buffer and
+ *   allocates nothing; Bluestein reuses the cached length-M buffer but still allocates its
+ *   convolution buffers on every call; the mixed-radix kernel calls RecursiveFFT, which allocates
+ *   fresh vectors on every call.
+ *
+ * Parameters:
+ *   in
+ *     The input vector, of length N;
+ *   out
+ *     The vector that receives the result, of length N.
+ **************************************************************************************************/
+func (p *Plan) Execute(in, out []complex128) {
+    N := p.N
+    copy(p.scratch, in)
+    if p.Dir == Inverse {
+        for i:=0; i<N; i++ {
+            p.scratch[i] = complex(real(p.scratch[i]), -imag(p.scratch[i]))
+        }
+    }
+
+    var result []complex128
+    switch p.kind {
+    case kernelRadix2:
+        result = p.executeRadix2()
+    case kernelMixedRadix:
+        result = RecursiveFFT(p.scratch)
+    case kernelBluestein:
+        result = p.executeBluestein()
+    }
+
+    if p.Dir == Inverse {
+        fN := float64(N)
+        for i:=0; i<N; i++ {
+            result[i] = complex(real(result[i])/fN, -imag(result[i])/fN)
+        }
+    }
+    copy(out, result)
+}
+
+
+/**************************************************************************************************
+ * Method: executeRadix2
+ *   Runs the iterative decimation in time butterflies directly on the plan's scratch buffer, using
+ *   the cached bit-reversal permutation instead of recomputing it on every call.
+ *
+ * Returns:
+ *   The plan's scratch buffer, transformed in place.
+ **************************************************************************************************/
+func (p *Plan) executeRadix2() []complex128 {
+    N := p.N
+    X := p.scratch
+
+    for k:=0; k<N; k++ {                       // Gather into bit-reversed order using the
+        l := p.bitrev[k]                       //   permutation cached at construction time;
+        if l > k {
+            X[k], X[l] = X[l], X[k]
+        }
+    }
+
+    step := 1                                  // W_(2.step)^n = W_N^(n.N/(2.step)), so every
+    for step < N {                             //   stage's twiddles come from the single cached
+        scale := N / (2 * step)                //   table for N instead of a fresh lookup;
+        for l:=0; l<N; l=l+2*step {
+            for n:=0; n<step; n++ {
+                Wkn := p.twiddles.At(n * scale)
+                pp := l + n
+                q := pp + step
+                X[q] = X[pp] - Wkn*X[q]
+                X[pp] = 2*X[pp] - X[q]
+            }
+        }
+        step = step << 1
+    }
+    return X
+}
+
+
+/**************************************************************************************************
+ * Method: executeBluestein
+ *   Runs Bluestein's convolution using the chirp and its cached forward transform, reusing the
+ *   plan's length-M buffer for the zero-padded operand instead of allocating it on every call.
+ *
+ * Returns:
+ *   A freshly allocated length-N vector with the transform (the convolution itself still needs
+ *   fresh buffers of length M, since its result can't alias the cached chirp transform).
+ **************************************************************************************************/
+func (p *Plan) executeBluestein() []complex128 {
+    N, M := p.N, p.M
+    x := p.scratch
+
+    for i := range p.pad {
+        p.pad[i] = 0
+    }
+    for n:=0; n<N; n++ {
+        p.pad[n] = x[n] * p.chirp[n]
+    }
+
+    A := IterativeFFT(p.pad)
+    C := make([]complex128, M)
+    for i:=0; i<M; i++ {
+        C[i] = A[i] * p.chirpFFT[i]
+    }
+    for i:=0; i<M; i++ {
+        C[i] = complex(real(C[i]), -imag(C[i]))
+    }
+    c := IterativeFFT(C)
+    for i:=0; i<M; i++ {
+        c[i] = complex(real(c[i])/float64(M), -imag(c[i])/float64(M))
+    }
+
+    X := make([]complex128, N)
+    for k:=0; k<N; k++ {
+        X[k] = p.chirp[k] * c[k]
+    }
+    return X
+}